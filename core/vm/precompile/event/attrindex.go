@@ -0,0 +1,60 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package event
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// `attrIndex` is a reusable `map[string]int` from a Cosmos event's attribute key to its position
+// in `event.Attributes`. Building one costs O(N) in the number of attributes; looking a key up in
+// it is O(1). `MakeTopics` and `MakeData` each build one per call via `getAttrIndex`, instead of
+// linearly scanning `event.Attributes` once per ABI argument.
+type attrIndex struct {
+	byKey map[string]int
+}
+
+// `attrIndexPool` lets `attrIndex` values be reused across event emissions so that building the
+// index doesn't allocate a fresh map on every call.
+var attrIndexPool = sync.Pool{
+	New: func() any { return &attrIndex{byKey: make(map[string]int)} },
+}
+
+// `getAttrIndex` returns a pooled `attrIndex` populated from `attrs`. Callers must call `Reset`
+// when done to return it to the pool.
+func getAttrIndex(attrs []sdk.Attribute) *attrIndex {
+	ai, _ := attrIndexPool.Get().(*attrIndex)
+	for i := range attrs {
+		ai.byKey[attrs[i].Key] = i
+	}
+	return ai
+}
+
+// `lookup` returns the position of `key` in the `event.Attributes` that `ai` was built from.
+func (ai *attrIndex) lookup(key string) (int, bool) {
+	i, ok := ai.byKey[key]
+	return i, ok
+}
+
+// `Reset` clears `ai` and returns it to `attrIndexPool` for reuse. It must be called exactly once
+// per `getAttrIndex` call, typically via `defer`.
+func (ai *attrIndex) Reset() {
+	for k := range ai.byKey {
+		delete(ai.byKey, k)
+	}
+	attrIndexPool.Put(ai)
+}
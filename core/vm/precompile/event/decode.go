@@ -0,0 +1,49 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package event
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/berachain/stargazer/common"
+)
+
+// `DecodeInto` decodes the string value of a Cosmos event attribute, `raw`, into `dst`. `dst`
+// must be a pointer to one of the Go types that `eventgen` maps Solidity event arguments to:
+// `*common.Address`, `*bool`, `*string`, `*[]byte`, or `**big.Int`. This is the typed counterpart
+// to the `ValueDecoders` used by the reflection-based path, and is called from the `BuildLog`
+// method that `eventgen` generates for each typed event.
+func DecodeInto(raw string, dst any) error {
+	switch d := dst.(type) {
+	case *common.Address:
+		*d = common.HexToAddress(raw)
+	case *bool:
+		*d = raw == "true"
+	case *string:
+		*d = raw
+	case *[]byte:
+		*d = []byte(raw)
+	case **big.Int:
+		n, ok := new(big.Int).SetString(raw, 10)
+		if !ok {
+			return fmt.Errorf("event: cannot decode %q as an integer", raw)
+		}
+		*d = n
+	default:
+		return fmt.Errorf("event: no decoder registered for destination type %T", dst)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/berachain/stargazer/common"
 	"github.com/berachain/stargazer/types/abi"
@@ -72,16 +73,26 @@ func (pe *PrecompileEvent) ModuleAddress() common.Address {
 // [eventID, indexed_arg1, ...]. Then this query is converted to topics using geth's
 // `abi.MakeTopics` function, which outputs hashes of all arguments in the query. The slice of
 // hashes is returned.
+//
+// NOTE: this builds one `attrIndex` over `event.Attributes` (O(N) in the number of attributes)
+// and does a single O(1) lookup per indexed argument, rather than re-scanning `event.Attributes`
+// for every argument.
 func (pe *PrecompileEvent) MakeTopics(event *sdk.Event) ([]common.Hash, error) {
+	ai := getAttrIndex(event.Attributes)
+	defer ai.Reset()
+	return pe.makeTopics(event, ai)
+}
+
+// `makeTopics` is `MakeTopics`'s body, taking an already-built `attrIndex` so that `BuildLog` can
+// build one `attrIndex` per Cosmos event and share it between `makeTopics` and `makeData`, rather
+// than each building and tearing down its own.
+func (pe *PrecompileEvent) makeTopics(event *sdk.Event, ai *attrIndex) ([]common.Hash, error) {
 	filterQuery := make([]any, len(pe.indexedInputs)+1)
 	filterQuery[0] = pe.id
 
-	// for each Ethereum indexed argument, get the corresponding Cosmos event attribute and
-	// convert to a geth compatible type. NOTE: this iteration has total complexity O(M), where
-	// M = average length of atrribute key strings, as length of `indexedInputs` <= 3.
 	for i, arg := range pe.indexedInputs {
-		attrIdx := searchAttributesForArg(&event.Attributes, arg.Name)
-		if attrIdx == notFound {
+		attrIdx, found := ai.lookup(arg.Name)
+		if !found {
 			return nil, fmt.Errorf(
 				"no attribute key found for event %s argument %s",
 				event.Type,
@@ -114,15 +125,25 @@ func (pe *PrecompileEvent) MakeTopics(event *sdk.Event) ([]common.Hash, error) {
 // bytes which store an Ethereum event's non-indexed arguments, packed into bytes. This function
 // packs the values of the incoming Cosmos event's attributes, which correspond to the
 // Ethereum event's non-indexed arguements, into bytes and returns a byte slice.
+//
+// NOTE: this builds one `attrIndex` over `event.Attributes` (O(N) in the number of attributes)
+// and does a single O(1) lookup per non-indexed argument, rather than re-scanning
+// `event.Attributes` for every argument (which made the previous implementation O(M*N^2)).
 func (pe *PrecompileEvent) MakeData(event *sdk.Event) ([]byte, error) {
+	ai := getAttrIndex(event.Attributes)
+	defer ai.Reset()
+	return pe.makeData(event, ai)
+}
+
+// `makeData` is `MakeData`'s body, taking an already-built `attrIndex` so that `BuildLog` can
+// build one `attrIndex` per Cosmos event and share it between `makeTopics` and `makeData`, rather
+// than each building and tearing down its own.
+func (pe *PrecompileEvent) makeData(event *sdk.Event, ai *attrIndex) ([]byte, error) {
 	attrVals := make([]any, len(pe.nonIndexedInputs))
 
-	// for each Ethereum non-indexed argument, get the corresponding Cosmos event attribute and
-	// convert to a geth compatible type. NOTE: the total complexity of this iteration: O(M*N^2),
-	// where N is the # of non-indexed args, M = average length of atrribute key strings.
 	for i, arg := range pe.nonIndexedInputs {
-		attrIdx := searchAttributesForArg(&event.Attributes, arg.Name)
-		if attrIdx == notFound {
+		attrIdx, found := ai.lookup(arg.Name)
+		if !found {
 			return nil, fmt.Errorf(
 				"no attribute key found for event %s argument %s",
 				event.Type,
@@ -151,6 +172,44 @@ func (pe *PrecompileEvent) MakeData(event *sdk.Event) ([]byte, error) {
 	return data, nil
 }
 
+// `BuildLog` builds the Ethereum log for a valid Cosmos `event`. If a generated, typed event has
+// been registered for `event.Type` (see `RegisterEvent`), that event's `BuildLog` is used;
+// otherwise this falls back to the reflection-based `MakeTopics`/`MakeData` path below. This lets
+// modules adopt `eventgen`-generated bindings one at a time.
+//
+// Every log `BuildLog` builds is also published to `logsFeed`, so that `eth_subscribe("logs",
+// ...)` clients (including those using the `Watch*`/`Filter*` methods `abigen` generates for a
+// precompile's ABI events) receive it exactly as they would a log emitted by a regular contract.
+func (pe *PrecompileEvent) BuildLog(event *sdk.Event) (*coretypes.Log, error) {
+	if generated, ok := lookupGeneratedEvent(event.Type); ok {
+		log, err := generated.BuildLog(event)
+		if err != nil {
+			return nil, err
+		}
+		publishLog(log)
+		return log, nil
+	}
+
+	ai := getAttrIndex(event.Attributes)
+	defer ai.Reset()
+
+	topics, err := pe.makeTopics(event, ai)
+	if err != nil {
+		return nil, err
+	}
+	data, err := pe.makeData(event, ai)
+	if err != nil {
+		return nil, err
+	}
+	log := &coretypes.Log{
+		Address: pe.moduleAddr,
+		Topics:  topics,
+		Data:    data,
+	}
+	publishLog(log)
+	return log, nil
+}
+
 // `ValidateAttributes` validates an incoming Cosmos `event`. Specifically, it verifies that the
 // number of attributes provided by the Cosmos `event` are adequate for it's corresponding
 // Ethereum events.
@@ -0,0 +1,100 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package event_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/berachain/stargazer/common"
+	"github.com/berachain/stargazer/types/abi"
+
+	"github.com/itsdevbear/polaris/core/vm/precompile/event"
+)
+
+// `distributionRewardsABI` mirrors the shape of `IDistributionModule`'s `WithdrawRewards` event:
+// one indexed argument plus several non-indexed ones, representative of the wide events emitted
+// by the distribution and staking modules.
+const distributionRewardsABI = `[{
+	"anonymous": false,
+	"name": "WithdrawRewards",
+	"type": "event",
+	"inputs": [
+		{"name": "delegator", "type": "address", "indexed": true},
+		{"name": "validator", "type": "string", "indexed": false},
+		{"name": "amount", "type": "uint256", "indexed": false},
+		{"name": "denom", "type": "string", "indexed": false},
+		{"name": "height", "type": "uint256", "indexed": false}
+	]
+}]`
+
+func mustPrecompileEvent(b *testing.B) *event.PrecompileEvent {
+	b.Helper()
+	parsed, err := gethabi.JSON(strings.NewReader(distributionRewardsABI))
+	if err != nil {
+		b.Fatalf("parsing abi: %v", err)
+	}
+	return event.NewPrecompileEvent(
+		common.HexToAddress("0x0000000000000000000000000000000000000069"),
+		abi.Event(parsed.Events["WithdrawRewards"]),
+		nil,
+	)
+}
+
+func mustWithdrawRewardsEvent() *sdk.Event {
+	return &sdk.Event{
+		Type: "withdraw_rewards",
+		Attributes: []sdk.Attribute{
+			{Key: "delegator", Value: "0x0000000000000000000000000000000000000001"},
+			{Key: "validator", Value: "bravalidatorvaloper1..."},
+			{Key: "amount", Value: "1000000000000000000"},
+			{Key: "denom", Value: "abera"},
+			{Key: "height", Value: fmt.Sprint(1)},
+		},
+	}
+}
+
+// `BenchmarkMakeTopics` measures `MakeTopics` for a representative distribution-module event.
+func BenchmarkMakeTopics(b *testing.B) {
+	pe := mustPrecompileEvent(b)
+	sdkEvent := mustWithdrawRewardsEvent()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pe.MakeTopics(sdkEvent); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// `BenchmarkMakeData` measures `MakeData` for a representative distribution-module event with
+// several non-indexed arguments, the case the pooled `attrIndex` lookup targets.
+func BenchmarkMakeData(b *testing.B) {
+	pe := mustPrecompileEvent(b)
+	sdkEvent := mustWithdrawRewardsEvent()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pe.MakeData(sdkEvent); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package event
+
+import (
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	gethevent "github.com/ethereum/go-ethereum/event"
+)
+
+// `logsFeed` is the package-level feed that every precompile-emitted Ethereum log is published
+// to from `BuildLog`. It lets a node's `eth_subscribe("logs", ...)` implementation deliver
+// precompile logs to subscribers the same way it delivers logs emitted by ordinary contracts,
+// including to clients using the `Watch*`/`Filter*` methods that `abigen` generates for
+// precompile ABI events.
+var logsFeed gethevent.Feed
+
+// `SubscribeLogs` registers `ch` to receive every Ethereum log that a precompile emits via
+// `BuildLog`, for as long as the returned `gethevent.Subscription` is not closed.
+func SubscribeLogs(ch chan<- *coretypes.Log) gethevent.Subscription {
+	return logsFeed.Subscribe(ch)
+}
+
+// `publishLog` delivers `log` to every subscriber registered via `SubscribeLogs`. It is a no-op,
+// other than the (cheap) feed send, when there are no subscribers.
+func publishLog(log *coretypes.Log) {
+	logsFeed.Send(log)
+}
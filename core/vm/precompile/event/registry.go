@@ -0,0 +1,61 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package event
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// `GeneratedEvent` is implemented by the typed event structs that the `eventgen` code generator
+// (see `host/cosmos/precompile/contracts/solidity/eventgen`) emits for each event of an
+// `I*Module.abi.json`. Unlike the reflection-based `ValueDecoders` path, a `GeneratedEvent`
+// builds its Ethereum log directly from concrete Go fields whose attribute keys are generated
+// from the ABI rather than written by hand. A Cosmos event missing one of those attributes is
+// still reported as an error at emission time.
+type GeneratedEvent interface {
+	// `BuildLog` decodes `event`'s Cosmos attributes into the generated event's typed fields and
+	// returns the corresponding Ethereum log.
+	BuildLog(event *sdk.Event) (*coretypes.Log, error)
+}
+
+// `eventRegistry` maps a Cosmos event type string to a constructor for the `GeneratedEvent`
+// registered for it. Entries are populated by generated code's `init` functions; nothing in this
+// package writes to it by hand.
+var eventRegistry = make(map[string]func() GeneratedEvent)
+
+// `RegisterEvent` registers `newEvent` as the constructor for the generated, typed event
+// corresponding to the Cosmos `eventType`. It is called from `eventgen`-generated code during
+// package `init` and panics on a duplicate registration, since that can only indicate a
+// generator bug.
+func RegisterEvent(eventType string, newEvent func() GeneratedEvent) {
+	if _, exists := eventRegistry[eventType]; exists {
+		panic(fmt.Sprintf("event: duplicate registration for event type %s", eventType))
+	}
+	eventRegistry[eventType] = newEvent
+}
+
+// `lookupGeneratedEvent` returns a freshly constructed `GeneratedEvent` for `eventType`, if one
+// has been registered. Modules that have not yet been migrated to `eventgen` fall back to the
+// reflection-based `ValueDecoders` path in `PrecompileEvent`.
+func lookupGeneratedEvent(eventType string) (GeneratedEvent, bool) {
+	newEvent, ok := eventRegistry[eventType]
+	if !ok {
+		return nil, false
+	}
+	return newEvent(), true
+}
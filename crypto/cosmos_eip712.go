@@ -0,0 +1,156 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	legacytx "github.com/cosmos/cosmos-sdk/x/auth/migrations/legacytx"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// `cosmosMsgValueTypes` maps a Cosmos `sdk.Msg`'s Amino name (e.g. "cosmos-sdk/MsgSend") to the
+// EIP-712 struct fields of its `value` object. Modules that want their messages to be signable
+// via `CosmosTxTypedData` register their shape here, mirroring how Ethermint-style chains
+// register a `MsgValue` type per message so MetaMask can render it.
+var cosmosMsgValueTypes = make(map[string][]TypedDataField)
+
+// `RegisterCosmosMsgType` registers the EIP-712 `value` fields for the `sdk.Msg` Amino type
+// `aminoName`. It must be called once per signable message type, typically from that message's
+// module `init`, before `CosmosTxTypedData` is used to wrap a transaction containing it.
+func RegisterCosmosMsgType(aminoName string, valueFields []TypedDataField) {
+	cosmosMsgValueTypes[aminoName] = valueFields
+}
+
+// `cosmosTxBaseTypes` are the fixed EIP-712 struct types shared by every Cosmos transaction
+// wrapped for Ethereum wallet signing, following the `StdSignDoc` shape used by MetaMask-style
+// Cosmos signing (as popularized by Ethermint's legacy EIP-712 support). `Tx` and each message's
+// `MsgN`/`MsgValueN` struct are built per call in `CosmosTxTypedData`, since their shape depends
+// on how many messages `tx.Msgs` holds and what type each one is.
+var cosmosTxBaseTypes = apitypes.Types{
+	"EIP712Domain": []TypedDataField{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "string"},
+		{Name: "salt", Type: "string"},
+	},
+	"Fee": []TypedDataField{
+		{Name: "amount", Type: "Coin[]"},
+		{Name: "gas", Type: "string"},
+	},
+	"Coin": []TypedDataField{
+		{Name: "denom", Type: "string"},
+		{Name: "amount", Type: "string"},
+	},
+}
+
+// `aminoMsg` is the shape of each entry in `legacytx.StdSignDoc.Msgs`: Amino-JSON encodes every
+// `sdk.Msg` as `{"type": "<amino name>", "value": {...}}`, which conveniently already matches
+// the `MsgN`/`MsgValueN` EIP-712 struct types below.
+type aminoMsg struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// `CosmosTxTypedData` builds the canonical EIP-712 `TypedData` payload for `tx`, in the
+// `StdSignDoc`-derived shape that MetaMask-style Ethereum wallets use to sign Cosmos
+// transactions. `tx.AccountNumber` and `tx.Sequence` are formatted as decimal strings, matching
+// the `Tx` type's `"string"`-typed fields below. Every message in `tx.Msgs` must have had its
+// `value` shape registered via `RegisterCosmosMsgType`, keyed by its Amino type name.
+//
+// The `EIP712Domain` type is always the five-field one in `cosmosTxBaseTypes`, so `domain` must
+// set `name`, `version`, `chainId`, `verifyingContract` and `salt`; hashing the result fails
+// for a domain that leaves any of them unset.
+//
+// EIP-712 requires every element of an array-typed field to share one struct type, so a `Msg[]`
+// field can't hold messages of different Amino types without each one checking its `value`
+// against the same declared shape. Since a `StdSignDoc` commonly carries messages of different
+// types (e.g. `MsgDelegate` and `MsgWithdrawDelegatorReward` in the same tx), each message i gets
+// its own `MsgN`/`MsgValueN` struct type and its own top-level `Tx` field (`msg0`, `msg1`, ...)
+// instead of a shared `msgs` array.
+func CosmosTxTypedData(
+	domain TypedDataDomain,
+	tx *legacytx.StdSignDoc,
+) (apitypes.TypedData, error) {
+	types := apitypes.Types{}
+	for name, fields := range cosmosTxBaseTypes {
+		types[name] = fields
+	}
+
+	var fee map[string]any
+	if err := json.Unmarshal(tx.Fee, &fee); err != nil {
+		return apitypes.TypedData{}, fmt.Errorf("crypto: decoding tx fee: %w", err)
+	}
+
+	txFields := []TypedDataField{
+		{Name: "account_number", Type: "string"},
+		{Name: "chain_id", Type: "string"},
+		{Name: "fee", Type: "Fee"},
+		{Name: "memo", Type: "string"},
+		{Name: "sequence", Type: "string"},
+	}
+	message := apitypes.TypedDataMessage{
+		"account_number": strconv.FormatUint(tx.AccountNumber, 10),
+		"chain_id":       tx.ChainID,
+		"fee":            fee,
+		"memo":           tx.Memo,
+		"sequence":       strconv.FormatUint(tx.Sequence, 10),
+	}
+
+	for i, raw := range tx.Msgs {
+		var msg aminoMsg
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return apitypes.TypedData{}, fmt.Errorf("crypto: decoding tx message %d: %w", i, err)
+		}
+
+		valueFields, ok := cosmosMsgValueTypes[msg.Type]
+		if !ok {
+			return apitypes.TypedData{}, fmt.Errorf(
+				"crypto: no EIP-712 value type registered for message type %s", msg.Type,
+			)
+		}
+
+		var value map[string]any
+		if err := json.Unmarshal(msg.Value, &value); err != nil {
+			return apitypes.TypedData{}, fmt.Errorf("crypto: decoding tx message %d value: %w", i, err)
+		}
+
+		msgTypeName := fmt.Sprintf("Msg%d", i)
+		msgValueTypeName := fmt.Sprintf("MsgValue%d", i)
+		types[msgTypeName] = []TypedDataField{
+			{Name: "type", Type: "string"},
+			{Name: "value", Type: msgValueTypeName},
+		}
+		types[msgValueTypeName] = valueFields
+
+		fieldName := fmt.Sprintf("msg%d", i)
+		txFields = append(txFields, TypedDataField{Name: fieldName, Type: msgTypeName})
+		message[fieldName] = map[string]any{
+			"type":  msg.Type,
+			"value": value,
+		}
+	}
+	types["Tx"] = txFields
+
+	return apitypes.TypedData{
+		Types:       types,
+		PrimaryType: "Tx",
+		Domain:      domain,
+		Message:     message,
+	}, nil
+}
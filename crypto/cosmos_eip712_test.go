@@ -0,0 +1,107 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package crypto_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	legacytx "github.com/cosmos/cosmos-sdk/x/auth/migrations/legacytx"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/itsdevbear/polaris/crypto"
+)
+
+func init() {
+	crypto.RegisterCosmosMsgType("cosmos-sdk/MsgSend", []crypto.TypedDataField{
+		{Name: "from_address", Type: "string"},
+		{Name: "to_address", Type: "string"},
+		{Name: "amount", Type: "Coin[]"},
+	})
+	crypto.RegisterCosmosMsgType("cosmos-sdk/MsgDelegate", []crypto.TypedDataField{
+		{Name: "delegator_address", Type: "string"},
+		{Name: "validator_address", Type: "string"},
+		{Name: "amount", Type: "Coin"},
+	})
+}
+
+// `TestCosmosTxTypedDataMixedMessageTypes` exercises the bug this series shipped: a single,
+// literal `"MsgValue"` types key was overwritten by every message after the first, so a tx
+// carrying more than one distinct message type silently lost the earlier ones' value schema. A
+// `MsgSend` + `MsgDelegate` tx must produce distinct `MsgN`/`MsgValueN` types and hash cleanly.
+func TestCosmosTxTypedDataMixedMessageTypes(t *testing.T) {
+	tx := &legacytx.StdSignDoc{
+		AccountNumber: 7,
+		ChainID:       "test-1",
+		Fee:           json.RawMessage(`{"amount":[{"denom":"abera","amount":"100"}],"gas":"200000"}`),
+		Sequence:      3,
+		Msgs: []json.RawMessage{
+			json.RawMessage(
+				`{"type":"cosmos-sdk/MsgSend","value":{"from_address":"cosmos1from","to_address":"cosmos1to","amount":[{"denom":"abera","amount":"1"}]}}`,
+			),
+			json.RawMessage(
+				`{"type":"cosmos-sdk/MsgDelegate","value":{"delegator_address":"cosmos1from","validator_address":"cosmosvaloper1val","amount":{"denom":"abera","amount":"1"}}}`,
+			),
+		},
+	}
+
+	domain := crypto.TypedDataDomain{
+		Name:              "Cosmos Web3",
+		Version:           "1.0.0",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: "cosmos",
+		Salt:              "0",
+	}
+	typedData, err := crypto.CosmosTxTypedData(domain, tx)
+	if err != nil {
+		t.Fatalf("CosmosTxTypedData: %v", err)
+	}
+
+	for _, name := range []string{"Msg0", "Msg1", "MsgValue0", "MsgValue1"} {
+		if _, ok := typedData.Types[name]; !ok {
+			t.Errorf("expected types to contain %q, got %v", name, typedData.Types)
+		}
+	}
+
+	if got := typedData.Message["account_number"]; got != "7" {
+		t.Errorf("account_number = %v, want \"7\"", got)
+	}
+	if got := typedData.Message["sequence"]; got != "3" {
+		t.Errorf("sequence = %v, want \"3\"", got)
+	}
+
+	fee, ok := typedData.Message["fee"].(map[string]any)
+	if !ok {
+		t.Fatalf("fee = %T, want map[string]any", typedData.Message["fee"])
+	}
+	if fee["gas"] != "200000" {
+		t.Errorf("fee.gas = %v, want \"200000\"", fee["gas"])
+	}
+
+	if _, _, err = apitypes.TypedDataAndHash(typedData); err != nil {
+		t.Fatalf("hashing mixed-message-type tx: %v", err)
+	}
+}
+
+func TestCosmosTxTypedDataUnregisteredMsgTypeErrors(t *testing.T) {
+	tx := &legacytx.StdSignDoc{
+		Fee:  json.RawMessage(`{"amount":[],"gas":"0"}`),
+		Msgs: []json.RawMessage{json.RawMessage(`{"type":"cosmos-sdk/MsgNeverRegistered","value":{}}`)},
+	}
+	if _, err := crypto.CosmosTxTypedData(crypto.TypedDataDomain{}, tx); err == nil {
+		t.Fatal("expected an error for an unregistered message type, got nil")
+	}
+}
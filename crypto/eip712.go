@@ -0,0 +1,114 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// `EncodeTypedData` returns the raw EIP-712 preimage for `message` -- `"\x19\x01" ||
+// domainSeparator || hashStruct(message)` -- before the final `Keccak256`. `HashTypedData` is
+// just `Keccak256` of this; it is exposed separately so that a signer which hashes internally
+// (e.g. a Ledger's `SignData`) can be handed the preimage instead of double-hashing it.
+func EncodeTypedData(
+	domain TypedDataDomain,
+	primaryType string,
+	message apitypes.TypedDataMessage,
+	types apitypes.Types,
+) ([]byte, error) {
+	typedData := apitypes.TypedData{
+		Types:       types,
+		PrimaryType: primaryType,
+		Domain:      domain,
+		Message:     message,
+	}
+	_, rawData, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: encoding typed data: %w", err)
+	}
+	return []byte(rawData), nil
+}
+
+// `TypedDataDomain` is the EIP-712 `domain` separator: the contract/chain-specific values mixed
+// into every typed-data hash to prevent cross-chain and cross-contract signature replay.
+type TypedDataDomain = apitypes.TypedDataDomain
+
+// `TypedDataField` describes one field of an EIP-712 struct type, e.g. `{Name: "owner", Type:
+// "address"}`.
+type TypedDataField = apitypes.Type
+
+// `HashTypedData` computes the EIP-712 hash of `message`, interpreted as the `primaryType`
+// struct described by `types` and signed over `domain`. The returned hash is what must be passed
+// to `EthSign` (or a hardware wallet's typed-data signing flow) to produce a valid EIP-712
+// signature.
+func HashTypedData(
+	domain TypedDataDomain,
+	primaryType string,
+	message apitypes.TypedDataMessage,
+	types apitypes.Types,
+) (common.Hash, error) {
+	typedData := apitypes.TypedData{
+		Types:       types,
+		PrimaryType: primaryType,
+		Domain:      domain,
+		Message:     message,
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("crypto: hashing typed data: %w", err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// `SignTypedData` signs the EIP-712 hash of `message` with `priv` and returns the 65-byte
+// `[R || S || V]` signature, in the same format as `EthSign`.
+func SignTypedData(
+	priv *ecdsa.PrivateKey,
+	domain TypedDataDomain,
+	primaryType string,
+	message apitypes.TypedDataMessage,
+	types apitypes.Types,
+) ([]byte, error) {
+	hash, err := HashTypedData(domain, primaryType, message, types)
+	if err != nil {
+		return nil, err
+	}
+	return EthSign(hash.Bytes(), priv)
+}
+
+// `RecoverTypedDataSigner` recovers the Ethereum address that produced `sig` over the EIP-712
+// hash of `message`.
+func RecoverTypedDataSigner(
+	domain TypedDataDomain,
+	primaryType string,
+	message apitypes.TypedDataMessage,
+	types apitypes.Types,
+	sig []byte,
+) (common.Address, error) {
+	hash, err := HashTypedData(domain, primaryType, message, types)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pubkey, err := gethcrypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("crypto: recovering typed data signer: %w", err)
+	}
+	return PubkeyToAddress(*pubkey), nil
+}
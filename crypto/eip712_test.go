@@ -0,0 +1,79 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/itsdevbear/polaris/crypto"
+)
+
+func testTypedData() (crypto.TypedDataDomain, apitypes.Types, apitypes.TypedDataMessage) {
+	domain := crypto.TypedDataDomain{Name: "Test", Version: "1"}
+	types := apitypes.Types{
+		"EIP712Domain": []crypto.TypedDataField{
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+		},
+		"Mail": []crypto.TypedDataField{
+			{Name: "contents", Type: "string"},
+		},
+	}
+	message := apitypes.TypedDataMessage{"contents": "hello"}
+	return domain, types, message
+}
+
+func TestSignTypedDataRecoversToSigner(t *testing.T) {
+	priv, err := crypto.GenerateEthKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	domain, types, message := testTypedData()
+
+	sig, err := crypto.SignTypedData(priv, domain, "Mail", message, types)
+	if err != nil {
+		t.Fatalf("SignTypedData: %v", err)
+	}
+
+	recovered, err := crypto.RecoverTypedDataSigner(domain, "Mail", message, types, sig)
+	if err != nil {
+		t.Fatalf("RecoverTypedDataSigner: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(priv.PublicKey); recovered != want {
+		t.Errorf("recovered signer = %s, want %s", recovered, want)
+	}
+}
+
+// `TestEncodeTypedDataMatchesHash` pins down the relationship `SignTypedData` on the Ledger path
+// (`crypto/hw`) relies on: `HashTypedData` must be exactly `Keccak256` of `EncodeTypedData`'s
+// preimage, so that `SignData` (which hashes internally) and `HashTypedData` (used everywhere
+// else) agree on what gets signed.
+func TestEncodeTypedDataMatchesHash(t *testing.T) {
+	domain, types, message := testTypedData()
+
+	rawData, err := crypto.EncodeTypedData(domain, "Mail", message, types)
+	if err != nil {
+		t.Fatalf("EncodeTypedData: %v", err)
+	}
+	hash, err := crypto.HashTypedData(domain, "Mail", message, types)
+	if err != nil {
+		t.Fatalf("HashTypedData: %v", err)
+	}
+	if got := crypto.Keccak256Hash(rawData); got != hash {
+		t.Errorf("Keccak256(EncodeTypedData(...)) = %s, want HashTypedData(...) = %s", got, hash)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// `hw` wraps a USB HID Ledger transport behind the same `Sign`/`PubkeyToAddress` shape as the
+// rest of the `crypto` package, so a node's JSON-RPC layer can be served by a plugged-in hardware
+// wallet without ever holding the corresponding private key in process memory.
+package hw
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/itsdevbear/polaris/crypto"
+)
+
+// `Wallet` is the shape a hardware-backed signer exposes to the rest of the node: derive an
+// address, sign a transaction, and sign EIP-712 typed data, all without the caller ever seeing a
+// private key.
+type Wallet interface {
+	// `Derive` returns the Ethereum address at `path`, optionally pinning it so that it is
+	// returned by future calls to `Accounts`.
+	Derive(path accounts.DerivationPath, pin bool) (gethcommon.Address, error)
+
+	// `Accounts` returns every address this wallet has pinned via `Derive`.
+	Accounts() []gethcommon.Address
+
+	// `SignTx` signs `tx` for the given `chainID` and returns the signed transaction.
+	SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// `PersonalSign` signs `message` under the EIP-191 personal-message prefix, backing the
+	// node's `personal_sign` RPC method. Unlike `SignTypedData`, `message` is signed as-is; it is
+	// not an EIP-712 hash.
+	PersonalSign(account accounts.Account, message []byte) ([]byte, error)
+
+	// `SignTypedData` signs the EIP-712 hash of `message`, pairing the wallet with
+	// `crypto.HashTypedData`.
+	SignTypedData(
+		account accounts.Account,
+		domain crypto.TypedDataDomain,
+		primaryType string,
+		message apitypes.TypedDataMessage,
+		types apitypes.Types,
+	) ([]byte, error)
+}
+
+// `Ledger` is a `Wallet` backed by a single USB HID Ledger device, via go-ethereum's
+// `accounts/usbwallet` transport.
+type Ledger struct {
+	wallet accounts.Wallet
+}
+
+// `OpenLedger` opens the first Ledger device found on `hub` and returns a `Ledger` wrapping it.
+// It returns an error if no device is plugged in or the device fails to open.
+func OpenLedger(hub *usbwallet.Hub) (*Ledger, error) {
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("hw: no Ledger device found")
+	}
+	wallet := wallets[0]
+	if err := wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("hw: opening ledger: %w", err)
+	}
+	return &Ledger{wallet: wallet}, nil
+}
+
+// `Derive` implements `Wallet`.
+func (l *Ledger) Derive(path accounts.DerivationPath, pin bool) (gethcommon.Address, error) {
+	account, err := l.wallet.Derive(path, pin)
+	if err != nil {
+		return gethcommon.Address{}, fmt.Errorf("hw: deriving account: %w", err)
+	}
+	return account.Address, nil
+}
+
+// `Accounts` implements `Wallet`.
+func (l *Ledger) Accounts() []gethcommon.Address {
+	accts := l.wallet.Accounts()
+	addrs := make([]gethcommon.Address, len(accts))
+	for i, account := range accts {
+		addrs[i] = account.Address
+	}
+	return addrs
+}
+
+// `SignTx` implements `Wallet`.
+func (l *Ledger) SignTx(
+	account accounts.Account, tx *types.Transaction, chainID *big.Int,
+) (*types.Transaction, error) {
+	signed, err := l.wallet.SignTx(account, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("hw: signing tx on ledger: %w", err)
+	}
+	return signed, nil
+}
+
+// `PersonalSign` implements `Wallet`. `message` is forwarded to the Ledger as-is; `SignText`
+// applies the EIP-191 `"\x19Ethereum Signed Message:\n" + len(message)` prefix the Ledger's
+// Ethereum app expects before signing, exactly as `personal_sign` requires.
+func (l *Ledger) PersonalSign(account accounts.Account, message []byte) ([]byte, error) {
+	sig, err := l.wallet.SignText(account, message)
+	if err != nil {
+		return nil, fmt.Errorf("hw: personal-signing on ledger: %w", err)
+	}
+	return sig, nil
+}
+
+// `SignTypedData` implements `Wallet`. `SignText` always applies the EIP-191 personal-message
+// prefix, which would double-prefix an already-hashed EIP-712 digest, so the raw
+// `"\x19\x01" || domainSeparator || hashStruct(message)` preimage is instead handed to
+// `SignData`, which signs its `Keccak256` directly -- the same digest `crypto.HashTypedData`
+// computes.
+func (l *Ledger) SignTypedData(
+	account accounts.Account,
+	domain crypto.TypedDataDomain,
+	primaryType string,
+	message apitypes.TypedDataMessage,
+	typesMap apitypes.Types,
+) ([]byte, error) {
+	rawData, err := crypto.EncodeTypedData(domain, primaryType, message, typesMap)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := l.wallet.SignData(account, accounts.MimetypeTypedData, rawData)
+	if err != nil {
+		return nil, fmt.Errorf("hw: signing typed data on ledger: %w", err)
+	}
+	return sig, nil
+}
+
+var _ Wallet = (*Ledger)(nil)
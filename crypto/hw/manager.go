@@ -0,0 +1,92 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hw
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/itsdevbear/polaris/crypto"
+)
+
+// `Manager` is a minimal `accounts.Manager`-style hub over a single `Wallet`. It lets a node's
+// `eth_accounts`, `eth_sendTransaction`, and `personal_sign` JSON-RPC handlers be served from a
+// plugged-in hardware wallet without any code path in the node ever needing the private key.
+type Manager struct {
+	wallet Wallet
+}
+
+// `NewManager` returns a `Manager` backed by `wallet`.
+func NewManager(wallet Wallet) *Manager {
+	return &Manager{wallet: wallet}
+}
+
+// `Accounts` backs the node's `eth_accounts` RPC method.
+func (m *Manager) Accounts() []gethcommon.Address {
+	return m.wallet.Accounts()
+}
+
+// `SendTransaction` backs the node's `eth_sendTransaction` RPC method: it signs `tx` as `from`
+// via the underlying hardware wallet and returns the signed transaction for the caller to
+// broadcast.
+func (m *Manager) SendTransaction(
+	from gethcommon.Address, tx *types.Transaction, chainID *big.Int,
+) (*types.Transaction, error) {
+	account, err := m.account(from)
+	if err != nil {
+		return nil, err
+	}
+	return m.wallet.SignTx(account, tx, chainID)
+}
+
+// `PersonalSign` backs the node's `personal_sign` RPC method: it signs `message` as `from` via
+// the underlying hardware wallet, under the EIP-191 personal-message prefix.
+func (m *Manager) PersonalSign(from gethcommon.Address, message []byte) ([]byte, error) {
+	account, err := m.account(from)
+	if err != nil {
+		return nil, err
+	}
+	return m.wallet.PersonalSign(account, message)
+}
+
+// `SignTypedData` backs the node's `eth_signTypedData` RPC method: it signs the EIP-712 hash of
+// `message` as `from` via the underlying hardware wallet.
+func (m *Manager) SignTypedData(
+	from gethcommon.Address,
+	domain crypto.TypedDataDomain,
+	primaryType string,
+	message apitypes.TypedDataMessage,
+	types apitypes.Types,
+) ([]byte, error) {
+	account, err := m.account(from)
+	if err != nil {
+		return nil, err
+	}
+	return m.wallet.SignTypedData(account, domain, primaryType, message, types)
+}
+
+func (m *Manager) account(addr gethcommon.Address) (accounts.Account, error) {
+	for _, a := range m.wallet.Accounts() {
+		if a == addr {
+			return accounts.Account{Address: addr}, nil
+		}
+	}
+	return accounts.Account{}, fmt.Errorf("hw: %s is not a known account on this wallet", addr)
+}
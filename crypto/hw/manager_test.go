@@ -0,0 +1,104 @@
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hw_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/itsdevbear/polaris/crypto"
+	"github.com/itsdevbear/polaris/crypto/hw"
+)
+
+// `fakeWallet` is a `hw.Wallet` that records which method was called and with which account,
+// rather than talking to real hardware.
+type fakeWallet struct {
+	accounts []gethcommon.Address
+
+	lastMethod  string
+	lastAccount accounts.Account
+}
+
+func (w *fakeWallet) Derive(accounts.DerivationPath, bool) (gethcommon.Address, error) {
+	return gethcommon.Address{}, fmt.Errorf("fakeWallet: Derive not implemented")
+}
+
+func (w *fakeWallet) Accounts() []gethcommon.Address { return w.accounts }
+
+func (w *fakeWallet) SignTx(
+	account accounts.Account, tx *types.Transaction, _ *big.Int,
+) (*types.Transaction, error) {
+	w.lastMethod, w.lastAccount = "SignTx", account
+	return tx, nil
+}
+
+func (w *fakeWallet) PersonalSign(account accounts.Account, message []byte) ([]byte, error) {
+	w.lastMethod, w.lastAccount = "PersonalSign", account
+	return message, nil
+}
+
+func (w *fakeWallet) SignTypedData(
+	account accounts.Account, _ crypto.TypedDataDomain, _ string,
+	_ apitypes.TypedDataMessage, _ apitypes.Types,
+) ([]byte, error) {
+	w.lastMethod, w.lastAccount = "SignTypedData", account
+	return []byte("sig"), nil
+}
+
+var _ hw.Wallet = (*fakeWallet)(nil)
+
+var testAddr = gethcommon.HexToAddress("0x0000000000000000000000000000000000000001")
+
+func TestManagerDispatchesToAccountOnWallet(t *testing.T) {
+	wallet := &fakeWallet{accounts: []gethcommon.Address{testAddr}}
+	manager := hw.NewManager(wallet)
+
+	if _, err := manager.PersonalSign(testAddr, []byte("hello")); err != nil {
+		t.Fatalf("PersonalSign: %v", err)
+	}
+	if wallet.lastMethod != "PersonalSign" || wallet.lastAccount.Address != testAddr {
+		t.Errorf("wallet saw %s(%s), want PersonalSign(%s)", wallet.lastMethod, wallet.lastAccount.Address, testAddr)
+	}
+
+	if _, err := manager.SignTypedData(
+		testAddr, crypto.TypedDataDomain{}, "Mail", apitypes.TypedDataMessage{}, apitypes.Types{},
+	); err != nil {
+		t.Fatalf("SignTypedData: %v", err)
+	}
+	if wallet.lastMethod != "SignTypedData" || wallet.lastAccount.Address != testAddr {
+		t.Errorf("wallet saw %s(%s), want SignTypedData(%s)", wallet.lastMethod, wallet.lastAccount.Address, testAddr)
+	}
+}
+
+// `TestManagerUnknownAccountErrors` guards the `Manager.account` lookup: signing on behalf of an
+// address the wallet never derived/pinned must fail, not silently dispatch to some other account.
+func TestManagerUnknownAccountErrors(t *testing.T) {
+	wallet := &fakeWallet{accounts: []gethcommon.Address{testAddr}}
+	manager := hw.NewManager(wallet)
+
+	unknown := gethcommon.HexToAddress("0x0000000000000000000000000000000000000002")
+	if _, err := manager.PersonalSign(unknown, []byte("hello")); err == nil {
+		t.Fatal("expected an error signing for an unknown account, got nil")
+	}
+	if wallet.lastMethod != "" {
+		t.Errorf("wallet should not have been dispatched to, but saw %s", wallet.lastMethod)
+	}
+}
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+// `eventgen` is a small code generator, run alongside `abigen` via `go:generate` in
+// `host/cosmos/precompile/contracts/solidity/gen.go`. For every event declared in an
+// `I*Module.abi.json`, it emits a typed `FooEvent` struct with one Go field per event argument,
+// plus a `BuildLog(sdk.Event) (*types.Log, error)` method that decodes a Cosmos event straight
+// into those fields and registers itself with `core/vm/precompile/event`'s registry. The
+// attribute keys and field types come from the ABI at generation time, replacing a hand-written
+// `ValueDecoders` table; a Cosmos event that lacks one of those keys still fails at runtime.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	abiPath    = flag.String("abi", "", "path to the I*Module.abi.json file to generate events from")
+	pkg        = flag.String("pkg", "generated", "package name for the generated file")
+	typ        = flag.String("type", "", "the abigen --type this module's bindings were generated with, e.g. StakingModule")
+	out        = flag.String("out", "", "output file path")
+	moduleAddr = flag.String("moduleAddr", "", "the module's Ethereum address, used as the log Address for its generated events")
+	eventTypes = flag.String("eventTypes", "", `comma-separated "EventName=cosmos_event_type" overrides for events whose live`+
+		` sdk.Event.Type isn't the snake_case of the ABI event name, e.g. "WithdrawRewards=withdraw_delegator_reward"`)
+)
+
+func main() {
+	flag.Parse()
+	if *abiPath == "" || *typ == "" || *out == "" || *moduleAddr == "" {
+		fmt.Fprintln(os.Stderr, "eventgen: -abi, -type, -out, and -moduleAddr are required")
+		os.Exit(1)
+	}
+	overrides, err := parseEventTypes(*eventTypes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventgen: -eventTypes: %v\n", err)
+		os.Exit(1)
+	}
+
+	raw, err := os.ReadFile(*abiPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventgen: reading abi: %v\n", err)
+		os.Exit(1)
+	}
+	parsed, err := ethabi.JSON(bytes.NewReader(raw))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventgen: parsing abi: %v\n", err)
+		os.Exit(1)
+	}
+
+	data := fileData{
+		Package:    *pkg,
+		MetaName:   *typ + "MetaData",
+		ModuleAddr: *moduleAddr,
+	}
+	for _, ev := range parsed.Events {
+		data.Events = append(data.Events, eventData(ev, overrides))
+	}
+
+	var buf bytes.Buffer
+	if err = fileTemplate.Execute(&buf, data); err != nil {
+		fmt.Fprintf(os.Stderr, "eventgen: executing template: %v\n", err)
+		os.Exit(1)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		// Write the unformatted source on failure so the error is easy to debug.
+		formatted = buf.Bytes()
+	}
+	if err = os.WriteFile(*out, formatted, 0o644); err != nil { //nolint:gosec // generated source file.
+		fmt.Fprintf(os.Stderr, "eventgen: writing output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// `eventField` is a single typed field of a generated event struct.
+type eventField struct {
+	GoName  string
+	GoType  string
+	Indexed bool
+	ArgName string
+}
+
+// `eventStruct` is the data needed to render one generated `FooEvent` type.
+type eventStruct struct {
+	StructName string
+	EventName  string
+	// `CosmosEventType` is the live `sdk.Event.Type` this event is emitted under at runtime, e.g.
+	// `"withdraw_rewards"` for the ABI event `WithdrawRewards`. The generated event registers and
+	// is looked up under this string, not `EventName`, since `BuildLog`'s registry lookup is keyed
+	// by the Cosmos event actually on the bus.
+	CosmosEventType string
+	Fields          []eventField
+}
+
+func eventData(ev ethabi.Event, eventTypeOverrides map[string]string) eventStruct {
+	cosmosEventType, ok := eventTypeOverrides[ev.Name]
+	if !ok {
+		cosmosEventType = snakeCase(ev.Name)
+	}
+	s := eventStruct{
+		StructName:      exportedName(ev.Name) + "Event",
+		EventName:       ev.Name,
+		CosmosEventType: cosmosEventType,
+	}
+	for _, arg := range ev.Inputs {
+		s.Fields = append(s.Fields, eventField{
+			GoName:  exportedName(arg.Name),
+			GoType:  goType(arg.Type.String()),
+			Indexed: arg.Indexed,
+			ArgName: arg.Name,
+		})
+	}
+	return s
+}
+
+func exportedName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// `snakeCase` converts a PascalCase ABI event name (e.g. `"WithdrawRewards"`) to the snake_case
+// Cosmos SDK convention its module typically emits the corresponding `sdk.Event.Type` under (e.g.
+// `"withdraw_rewards"`). Modules that deviate from this convention can override it per-event via
+// `-eventTypes`.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r - 'A' + 'a')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// `parseEventTypes` parses the `-eventTypes` flag's `"Name=type,Name2=type2"` syntax into a map.
+func parseEventTypes(raw string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	if raw == "" {
+		return overrides, nil
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		name, cosmosType, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not of the form Name=cosmos_event_type", pair)
+		}
+		overrides[name] = cosmosType
+	}
+	return overrides, nil
+}
+
+// `goType` maps the Solidity types used by the existing `I*Module` ABIs to their Go equivalent.
+// Types outside this small set fall back to the geth ABI package's catch-all `any`, which keeps
+// generation total while still typing the common cases precisely.
+func goType(solType string) string {
+	switch {
+	case solType == "address":
+		return "common.Address"
+	case solType == "bool":
+		return "bool"
+	case solType == "string":
+		return "string"
+	case solType == "bytes" || strings.HasPrefix(solType, "bytes"):
+		return "[]byte"
+	case strings.HasPrefix(solType, "uint") || strings.HasPrefix(solType, "int"):
+		return "*big.Int"
+	default:
+		return "any"
+	}
+}
+
+type fileData struct {
+	Package    string
+	MetaName   string
+	ModuleAddr string
+	Events     []eventStruct
+}
+
+var fileTemplate = template.Must(template.New("eventgen").Parse(`// Code generated by eventgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/berachain/stargazer/common"
+	precompileevent "github.com/itsdevbear/polaris/core/vm/precompile/event"
+)
+
+var {{.MetaName}}ParsedABI = func() ethabi.ABI {
+	parsed, err := ethabi.JSON(strings.NewReader({{.MetaName}}.ABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+{{range .Events}}
+// `{{.StructName}}` is the typed Go representation of the {{.EventName}} Ethereum event.
+type {{.StructName}} struct {
+{{range .Fields}}	{{.GoName}} {{.GoType}}
+{{end}}}
+
+// `BuildLog` decodes `event`'s Cosmos attributes directly into a `{{.StructName}}`'s typed fields,
+// then builds the corresponding Ethereum log. Unlike the reflection-based path, each attribute is
+// decoded straight into the field eventgen generated for it, with no per-attribute decoder
+// lookup. Attributes are still found by key at runtime: a missing attribute is returned as an
+// error when the event is built.
+func (e *{{.StructName}}) BuildLog(event *sdk.Event) (*coretypes.Log, error) {
+	abiEvent := {{$.MetaName}}ParsedABI.Events["{{.EventName}}"]
+
+	attrs := make(map[string]string, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+{{range .Fields}}
+	if raw, ok := attrs["{{.ArgName}}"]; ok {
+		if err := precompileevent.DecodeInto(raw, &e.{{.GoName}}); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("{{$.MetaName}}: missing attribute %q for event {{$.MetaName}}.{{.EventName}}", "{{.ArgName}}")
+	}
+{{end}}
+	filterQuery := []any{abiEvent.ID}
+{{range .Fields}}{{if .Indexed}}	filterQuery = append(filterQuery, e.{{.GoName}})
+{{end}}{{end}}	topics, err := ethabi.MakeTopics(filterQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	nonIndexedArgs := []any{
+{{range .Fields}}{{if not .Indexed}}		e.{{.GoName}},
+{{end}}{{end}}	}
+	data, err := abiEvent.Inputs.NonIndexed().PackValues(nonIndexedArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coretypes.Log{
+		Address: common.HexToAddress("{{$.ModuleAddr}}"),
+		Topics:  topics[0],
+		Data:    data,
+	}, nil
+}
+{{end}}
+func init() {
+{{range .Events}}	precompileevent.RegisterEvent("{{.CosmosEventType}}", func() precompileevent.GeneratedEvent { return new({{.StructName}}) })
+{{end}}}
+`))
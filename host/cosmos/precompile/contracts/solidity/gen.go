@@ -24,3 +24,15 @@ package solidity
 //go:generate abigen --pkg generated --abi ./out/bank.sol/IBankModule.abi.json --bin ./out/bank.sol/IbankModule.bin --out ./generated/i_bank_module.abigen.go --type BankModule
 //go:generate abigen --pkg generated --abi ./out/address.sol/IAddress.abi.json --bin ./out/address.sol/IAddress.bin --out ./generated/i_address.abigen.go --type Address
 //go:generate abigen --pkg generated --abi ./out/distribution.sol/IDistributionModule.abi.json --bin ./out/distribution.sol/IDistributionModule.bin --out ./generated/i_distribution_module.abigen.go --type DistributionModule
+//go:generate abigen --pkg generated --abi ./out/funtoken.sol/IFunToken.abi.json --bin ./out/funtoken.sol/IFunToken.bin --out ./generated/i_funtoken.abigen.go --type FunToken
+
+// `eventgen` runs after `abigen` and reads the same ABI JSON, so the `StakingModuleMetaData` etc.
+// structs it references are always in scope by the time these directives run. `-moduleAddr` is
+// each Cosmos module's fixed Ethereum address, so a generated event's log always reports its
+// module as the log `Address`, the same way `funtoken`'s precompile address does. Each generated
+// event registers itself under the snake_case of its ABI name (e.g. `WithdrawRewards` ->
+// `withdraw_rewards`), which is the live `sdk.Event.Type` these modules emit; pass `-eventTypes
+// Name=cosmos_event_type` for any event whose module deviates from that convention.
+//go:generate go run ./eventgen --pkg generated --abi ./out/staking.sol/IStakingModule.abi.json --out ./generated/i_staking_module.events.go --type StakingModule --moduleAddr 0x0000000000000000000000000000000000000066
+//go:generate go run ./eventgen --pkg generated --abi ./out/bank.sol/IBankModule.abi.json --out ./generated/i_bank_module.events.go --type BankModule --moduleAddr 0x0000000000000000000000000000000000000067
+//go:generate go run ./eventgen --pkg generated --abi ./out/distribution.sol/IDistributionModule.abi.json --out ./generated/i_distribution_module.events.go --type DistributionModule --moduleAddr 0x0000000000000000000000000000000000000068
@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+// `funtoken` implements an ERC-20-compatible precompile over a single bank-module denom, so that
+// ordinary Ethereum contracts and wallets can `transfer`/`balanceOf`/`approve` a Cosmos coin as
+// if it were an ERC-20 token ("fungible token" -> funtoken).
+package funtoken
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmath "cosmossdk.io/math"
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/berachain/stargazer/common"
+	"github.com/berachain/stargazer/types/abi"
+
+	precompileevent "github.com/itsdevbear/polaris/core/vm/precompile/event"
+	polariscrypto "github.com/itsdevbear/polaris/crypto"
+)
+
+// `BankKeeper` is the narrow slice of the bank module's keeper that a `FunToken` needs to back
+// its ERC-20 methods with bank-module state.
+type BankKeeper interface {
+	SendCoins(ctx sdk.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) error
+	GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin
+	GetSupply(ctx sdk.Context, denom string) sdk.Coin
+}
+
+// `erc20EventsABI` declares the standard ERC-20 `Transfer`/`Approval` events. Argument names are
+// chosen to match this package's own `valueDecoders` rather than any particular Cosmos module's
+// attribute keys, since a `FunToken`'s events are synthesized by this package, not re-emitted
+// from a Cosmos event already on the bus.
+const erc20EventsABI = `[
+	{"anonymous":false,"name":"Transfer","type":"event","inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}
+	]},
+	{"anonymous":false,"name":"Approval","type":"event","inputs":[
+		{"name":"owner","type":"address","indexed":true},
+		{"name":"spender","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}
+	]}
+]`
+
+var erc20Events = func() gethabi.ABI {
+	parsed, err := gethabi.JSON(strings.NewReader(erc20EventsABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// `valueDecoders` decode the attribute values this package puts on the synthetic `sdk.Event`s it
+// passes to `PrecompileEvent.BuildLog`.
+var valueDecoders = precompileevent.ValueDecoders{
+	"from":    decodeAddress,
+	"to":      decodeAddress,
+	"owner":   decodeAddress,
+	"spender": decodeAddress,
+	"value":   decodeUint256,
+}
+
+func decodeAddress(raw string) (any, error) {
+	return common.HexToAddress(raw), nil
+}
+
+func decodeUint256(raw string) (any, error) {
+	n, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return nil, fmt.Errorf("funtoken: %q is not a valid uint256", raw)
+	}
+	return n, nil
+}
+
+// `FunToken` is the ERC-20-compatible precompile backing a single bank-module `denom`.
+type FunToken struct {
+	denom      string
+	address    common.Address
+	bankKeeper BankKeeper
+
+	transferEvent *precompileevent.PrecompileEvent
+	approvalEvent *precompileevent.PrecompileEvent
+
+	// `allowances` holds ERC-20 `approve`/`transferFrom` allowances, a concept the bank module
+	// itself has no notion of.
+	allowances map[common.Address]map[common.Address]*big.Int
+}
+
+// `Denom` returns the bank-module denom this `FunToken` wraps.
+func (ft *FunToken) Denom() string { return ft.denom }
+
+// `Address` returns the Ethereum address that Transfer/Approval logs are emitted under, matching
+// the address a standard ERC-20 indexer expects a token's logs to come from.
+func (ft *FunToken) Address() common.Address { return ft.address }
+
+// `TotalSupply` returns the bank-module supply of `ft`'s denom.
+func (ft *FunToken) TotalSupply(ctx sdk.Context) *big.Int {
+	return ft.bankKeeper.GetSupply(ctx, ft.denom).Amount.BigInt()
+}
+
+// `BalanceOf` returns `account`'s bank-module balance of `ft`'s denom.
+func (ft *FunToken) BalanceOf(ctx sdk.Context, account common.Address) *big.Int {
+	return ft.bankKeeper.GetBalance(ctx, sdk.AccAddress(account.Bytes()), ft.denom).Amount.BigInt()
+}
+
+// `Transfer` moves `amount` of `ft`'s denom from `from` to `to` via the bank module, and returns
+// the ERC-20 `Transfer` log for the move.
+func (ft *FunToken) Transfer(
+	ctx sdk.Context, from, to common.Address, amount *big.Int,
+) (*coretypes.Log, error) {
+	coins := sdk.NewCoins(sdk.NewCoin(ft.denom, sdkmath.NewIntFromBigInt(amount)))
+	if err := ft.bankKeeper.SendCoins(
+		ctx, sdk.AccAddress(from.Bytes()), sdk.AccAddress(to.Bytes()), coins,
+	); err != nil {
+		return nil, err
+	}
+	return ft.transferEvent.BuildLog(&sdk.Event{
+		Type: "fun_token_transfer",
+		Attributes: []sdk.Attribute{
+			{Key: "from", Value: from.Hex()},
+			{Key: "to", Value: to.Hex()},
+			{Key: "value", Value: amount.String()},
+		},
+	})
+}
+
+// `Approve` sets `spender`'s allowance over `owner`'s balance of `ft`'s denom to `amount`, and
+// returns the ERC-20 `Approval` log for the change.
+func (ft *FunToken) Approve(owner, spender common.Address, amount *big.Int) (*coretypes.Log, error) {
+	if ft.allowances[owner] == nil {
+		ft.allowances[owner] = make(map[common.Address]*big.Int)
+	}
+	ft.allowances[owner][spender] = new(big.Int).Set(amount)
+
+	return ft.approvalEvent.BuildLog(&sdk.Event{
+		Type: "fun_token_approval",
+		Attributes: []sdk.Attribute{
+			{Key: "owner", Value: owner.Hex()},
+			{Key: "spender", Value: spender.Hex()},
+			{Key: "value", Value: amount.String()},
+		},
+	})
+}
+
+// `Allowance` returns the amount `spender` may still transfer out of `owner`'s balance.
+func (ft *FunToken) Allowance(owner, spender common.Address) *big.Int {
+	if allowed, ok := ft.allowances[owner][spender]; ok {
+		return new(big.Int).Set(allowed)
+	}
+	return new(big.Int)
+}
+
+// `TransferFrom` moves `amount` of `ft`'s denom from `from` to `to` on `spender`'s behalf,
+// deducting from the allowance `from` granted `spender` via `Approve`.
+func (ft *FunToken) TransferFrom(
+	ctx sdk.Context, spender, from, to common.Address, amount *big.Int,
+) (*coretypes.Log, error) {
+	allowed := ft.Allowance(from, spender)
+	if allowed.Cmp(amount) < 0 {
+		return nil, fmt.Errorf("funtoken: transfer amount %s exceeds allowance %s", amount, allowed)
+	}
+	if ft.allowances[from] == nil {
+		ft.allowances[from] = make(map[common.Address]*big.Int)
+	}
+	ft.allowances[from][spender] = new(big.Int).Sub(allowed, amount)
+	return ft.Transfer(ctx, from, to, amount)
+}
+
+// `Deposit` sends `amount` of `ft`'s denom from `depositor` into `recipient`'s fun-token balance,
+// the reverse path of `Transfer`: a way to move native coins a depositor already holds into an
+// address's ERC-20-visible balance, rather than moving balance an owner already holds through
+// `transfer`/`transferFrom`. Since a `FunToken`'s balance is backed one-to-one by the bank
+// module, this is `Transfer` under the name the `IFunToken` Solidity interface's
+// `deposit(address,uint256)` entry point expects.
+func (ft *FunToken) Deposit(
+	ctx sdk.Context, depositor, recipient common.Address, amount *big.Int,
+) (*coretypes.Log, error) {
+	return ft.Transfer(ctx, depositor, recipient, amount)
+}
+
+// `Factory` deploys (i.e. registers) and looks up `FunToken` precompiles by bank denom, backing
+// the `createFunToken(string denom)` method of the `IFunToken` Solidity interface.
+type Factory struct {
+	bankKeeper BankKeeper
+	byDenom    map[string]*FunToken
+}
+
+// `NewFactory` returns a new `Factory` backed by `bankKeeper`.
+func NewFactory(bankKeeper BankKeeper) *Factory {
+	return &Factory{
+		bankKeeper: bankKeeper,
+		byDenom:    make(map[string]*FunToken),
+	}
+}
+
+// `CreateFunToken` returns the `FunToken` precompile for `denom`, deploying one the first time
+// it's requested for that denom and returning the existing one on every call after.
+func (f *Factory) CreateFunToken(denom string) *FunToken {
+	if ft, ok := f.byDenom[denom]; ok {
+		return ft
+	}
+
+	addr := funTokenAddress(denom)
+	ft := &FunToken{
+		denom:      denom,
+		address:    addr,
+		bankKeeper: f.bankKeeper,
+		transferEvent: precompileevent.NewPrecompileEvent(
+			addr, abi.Event(erc20Events.Events["Transfer"]), valueDecoders,
+		),
+		approvalEvent: precompileevent.NewPrecompileEvent(
+			addr, abi.Event(erc20Events.Events["Approval"]), valueDecoders,
+		),
+		allowances: make(map[common.Address]map[common.Address]*big.Int),
+	}
+	f.byDenom[denom] = ft
+	return ft
+}
+
+// `funTokenAddress` deterministically derives the Ethereum address a `FunToken` for `denom` is
+// deployed to, analogous to a CREATE2 factory address.
+func funTokenAddress(denom string) common.Address {
+	return common.BytesToAddress(polariscrypto.Keccak256Hash([]byte("funtoken/" + denom)).Bytes()[12:])
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+package funtoken_test
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/berachain/stargazer/common"
+
+	"github.com/itsdevbear/polaris/host/cosmos/precompile/funtoken"
+)
+
+const testDenom = "utest"
+
+// `fakeBankKeeper` is a minimal in-memory `funtoken.BankKeeper` used to exercise `FunToken`
+// without depending on a real bank-module keeper.
+type fakeBankKeeper struct {
+	balances map[string]*big.Int
+}
+
+func newFakeBankKeeper() *fakeBankKeeper {
+	return &fakeBankKeeper{balances: make(map[string]*big.Int)}
+}
+
+func (k *fakeBankKeeper) balanceOf(addr sdk.AccAddress) *big.Int {
+	if b, ok := k.balances[addr.String()]; ok {
+		return b
+	}
+	return new(big.Int)
+}
+
+func (k *fakeBankKeeper) SendCoins(_ sdk.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	amount := amt.AmountOf(testDenom).BigInt()
+	k.balances[fromAddr.String()] = new(big.Int).Sub(k.balanceOf(fromAddr), amount)
+	k.balances[toAddr.String()] = new(big.Int).Add(k.balanceOf(toAddr), amount)
+	return nil
+}
+
+func (k *fakeBankKeeper) GetBalance(_ sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, sdkmath.NewIntFromBigInt(k.balanceOf(addr)))
+}
+
+func (k *fakeBankKeeper) GetSupply(_ sdk.Context, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, sdkmath.ZeroInt())
+}
+
+var _ funtoken.BankKeeper = (*fakeBankKeeper)(nil)
+
+// `TestTransferFromWithoutPriorApprovalDoesNotPanic` is a regression test for a crash: calling
+// `TransferFrom` for a `(from, spender)` pair that never called `Approve` panicked with
+// "assignment to entry in nil map", since `Allowance` reports an unset pair as a valid 0 (so a
+// 0-amount `transferFrom` passed the allowance check) but the write back into
+// `ft.allowances[from]` assumed that map was already initialized.
+func TestTransferFromWithoutPriorApprovalDoesNotPanic(t *testing.T) {
+	factory := funtoken.NewFactory(newFakeBankKeeper())
+	ft := factory.CreateFunToken(testDenom)
+
+	from := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	spender := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	if _, err := ft.TransferFrom(sdk.Context{}, spender, from, to, big.NewInt(0)); err != nil {
+		t.Fatalf("TransferFrom with no prior approval and a 0 amount: %v", err)
+	}
+}
+
+func TestTransferFromExceedingAllowanceErrors(t *testing.T) {
+	factory := funtoken.NewFactory(newFakeBankKeeper())
+	ft := factory.CreateFunToken(testDenom)
+
+	from := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	spender := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	if _, err := ft.TransferFrom(sdk.Context{}, spender, from, to, big.NewInt(1)); err == nil {
+		t.Fatal("expected an error for a transferFrom exceeding a 0 allowance, got nil")
+	}
+}
+
+func TestApproveThenTransferFromDeductsAllowance(t *testing.T) {
+	bank := newFakeBankKeeper()
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	bank.balances[sdk.AccAddress(owner.Bytes()).String()] = big.NewInt(100)
+
+	factory := funtoken.NewFactory(bank)
+	ft := factory.CreateFunToken(testDenom)
+
+	spender := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	if _, err := ft.Approve(owner, spender, big.NewInt(40)); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if got := ft.Allowance(owner, spender); got.Cmp(big.NewInt(40)) != 0 {
+		t.Fatalf("Allowance = %s, want 40", got)
+	}
+
+	if _, err := ft.TransferFrom(sdk.Context{}, spender, owner, to, big.NewInt(30)); err != nil {
+		t.Fatalf("TransferFrom: %v", err)
+	}
+	if got := ft.Allowance(owner, spender); got.Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("remaining allowance = %s, want 10", got)
+	}
+	if got := ft.BalanceOf(sdk.Context{}, to); got.Cmp(big.NewInt(30)) != 0 {
+		t.Fatalf("recipient balance = %s, want 30", got)
+	}
+}
+
+func TestDepositIsAnAliasForTransfer(t *testing.T) {
+	bank := newFakeBankKeeper()
+	depositor := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	bank.balances[sdk.AccAddress(depositor.Bytes()).String()] = big.NewInt(50)
+
+	factory := funtoken.NewFactory(bank)
+	ft := factory.CreateFunToken(testDenom)
+
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	if _, err := ft.Deposit(sdk.Context{}, depositor, recipient, big.NewInt(20)); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	if got := ft.BalanceOf(sdk.Context{}, recipient); got.Cmp(big.NewInt(20)) != 0 {
+		t.Fatalf("recipient balance = %s, want 20", got)
+	}
+}
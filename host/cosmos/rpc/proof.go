@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: BUSL-1.1
+//
+// Copyright (C) 2023, Berachain Foundation. All rights reserved.
+// Use of this software is govered by the Business Source License included
+// in the LICENSE file of this repository and at www.mariadb.com/bsl11.
+//
+// ANY USE OF THE LICENSED WORK IN VIOLATION OF THIS LICENSE WILL AUTOMATICALLY
+// TERMINATE YOUR RIGHTS UNDER THIS LICENSE FOR THE CURRENT AND ALL OTHER
+// VERSIONS OF THE LICENSED WORK.
+//
+// THIS LICENSE DOES NOT GRANT YOU ANY RIGHT IN ANY TRADEMARK OR LOGO OF
+// LICENSOR OR ITS AFFILIATES (PROVIDED THAT YOU MAY USE A TRADEMARK OR LOGO OF
+// LICENSOR AS EXPRESSLY REQUIRED BY THIS LICENSE).
+//
+// TO THE EXTENT PERMITTED BY APPLICABLE LAW, THE LICENSED WORK IS PROVIDED ON
+// AN “AS IS” BASIS. LICENSOR HEREBY DISCLAIMS ALL WARRANTIES AND CONDITIONS,
+// EXPRESS OR IMPLIED, INCLUDING (WITHOUT LIMITATION) WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE, NON-INFRINGEMENT, AND
+// TITLE.
+
+// `rpc` hosts the glue between Polaris's state layer and the node's Ethereum JSON-RPC surface.
+// `GetProof` is the first method here: it builds the `eth_getProof` result in the exact EIP-1186
+// shape geth clients expect, rather than a Cosmos-IAVL-shaped proof.
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// `StateAtHeight` is the narrow slice of the state/keeper layer that `GetProof` needs: a
+// Merkle-Patricia view of accounts and their storage at a single height. Keeping this as an
+// interface, rather than depending on a concrete keeper type, lets `GetProof` be exercised
+// against any trie-backed state view, including a plain `trie.Trie` in tests.
+type StateAtHeight interface {
+	// `StateRoot` is the root hash of the account trie at this height.
+	StateRoot() common.Hash
+	// `AccountTrie` returns the account trie at this height.
+	AccountTrie() (*trie.Trie, error)
+	// `Account` returns the RLP-encoded state account for `address`, or nil if it does not
+	// exist.
+	Account(address common.Address) ([]byte, error)
+	// `StorageTrie` returns `address`'s storage trie at this height. It returns nil if
+	// `address` has no code/storage.
+	StorageTrie(address common.Address) (*trie.Trie, error)
+}
+
+// `account` mirrors the fields of `types.StateAccount` that `GetProof` needs to decode out of
+// the RLP value `StateAtHeight.Account` returns. `Balance` must be `*big.Int`, not `*hexutil.Big`:
+// the `rlp` package only special-cases the exact `big.Int` type, so decoding into a distinct
+// named type like `hexutil.Big` silently fails to hit that fast path.
+type account struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+// `StorageResult` is the EIP-1186 shape of a single storage slot's proof.
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// `AccountResult` is the `eth_getProof` result, matching EIP-1186 exactly: hex-encoded proof
+// node arrays, and values padded to the widths geth's own `eth_getProof` implementation uses.
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// `GetProof` builds the `eth_getProof` result for `address` and `storageKeys`, as of `state`. It
+// runs `trie.Prove` against `state`'s account trie and, for an existing account, its storage
+// trie, so the returned proofs verify against `state.StateRoot()` using `trie.VerifyProof`.
+func GetProof(
+	state StateAtHeight, address common.Address, storageKeys []common.Hash,
+) (*AccountResult, error) {
+	accountTrie, err := state.AccountTrie()
+	if err != nil {
+		return nil, fmt.Errorf("rpc: loading account trie: %w", err)
+	}
+
+	accountProof, err := proveKey(accountTrie, gethcrypto.Keccak256(address.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("rpc: proving account %s: %w", address, err)
+	}
+
+	raw, err := state.Account(address)
+	if err != nil {
+		return nil, fmt.Errorf("rpc: loading account %s: %w", address, err)
+	}
+
+	result := &AccountResult{
+		Address:      address,
+		AccountProof: accountProof,
+		Balance:      (*hexutil.Big)(new(big.Int)),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+	}
+	if raw == nil {
+		// EIP-1186 requires a well-formed, empty-account result for an address with no state,
+		// rather than an error.
+		result.StorageProof = make([]StorageResult, len(storageKeys))
+		for i, key := range storageKeys {
+			result.StorageProof[i] = StorageResult{Key: key.Hex(), Value: (*hexutil.Big)(new(big.Int))}
+		}
+		return result, nil
+	}
+
+	var acc account
+	if err = rlp.DecodeBytes(raw, &acc); err != nil {
+		return nil, fmt.Errorf("rpc: decoding account %s: %w", address, err)
+	}
+	result.Balance = (*hexutil.Big)(acc.Balance)
+	result.Nonce = hexutil.Uint64(acc.Nonce)
+	result.CodeHash = common.BytesToHash(acc.CodeHash)
+	result.StorageHash = acc.Root
+
+	storageTrie, err := state.StorageTrie(address)
+	if err != nil {
+		return nil, fmt.Errorf("rpc: loading storage trie for %s: %w", address, err)
+	}
+
+	result.StorageProof = make([]StorageResult, len(storageKeys))
+	for i, key := range storageKeys {
+		proof, value, err := proveStorage(storageTrie, key)
+		if err != nil {
+			return nil, fmt.Errorf("rpc: proving storage key %s for %s: %w", key, address, err)
+		}
+		result.StorageProof[i] = StorageResult{
+			Key:   key.Hex(),
+			Value: (*hexutil.Big)(value),
+			Proof: proof,
+		}
+	}
+	return result, nil
+}
+
+// `proveStorage` runs `trie.Prove` for `key` against `storageTrie` (which may be nil for an
+// account with no storage) and returns the hex-encoded proof alongside the decoded slot value as
+// a `*big.Int`; the value is not padded to 32 bytes.
+func proveStorage(storageTrie *trie.Trie, key common.Hash) ([]string, *big.Int, error) {
+	if storageTrie == nil {
+		return nil, new(big.Int), nil
+	}
+
+	hashedKey := gethcrypto.Keccak256(key.Bytes())
+	proof, err := proveKey(storageTrie, hashedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := storageTrie.Get(hashedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	value := new(big.Int)
+	if len(raw) > 0 {
+		var slot []byte
+		if err = rlp.DecodeBytes(raw, &slot); err != nil {
+			return nil, nil, err
+		}
+		value.SetBytes(slot)
+	}
+	return proof, value, nil
+}
+
+// `proveKey` collects the Merkle proof nodes for `key` in `t`, hex-encoded in the order
+// `trie.VerifyProof` expects.
+func proveKey(t *trie.Trie, key []byte) ([]string, error) {
+	proofDB := memorydb.New()
+	if err := t.Prove(key, proofDB); err != nil {
+		return nil, err
+	}
+
+	it := proofDB.NewIterator(nil, nil)
+	defer it.Release()
+
+	nodes := make([]string, 0, 8)
+	for it.Next() {
+		nodes = append(nodes, hexutil.Encode(it.Value()))
+	}
+	return nodes, nil
+}